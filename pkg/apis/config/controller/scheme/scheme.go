@@ -0,0 +1,70 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scheme knows how to decode a ControllerConfiguration of any known API version from the
+// file passed via --config, convert it to the internal config.Config, and validate the result.
+package scheme
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	v1alpha1 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/config/controller/v1alpha1"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config/validation"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+}
+
+// Load reads a ControllerConfiguration from the file at path, decodes it as any known API version,
+// converts it to the internal config.Config, applies defaults and validates the result.
+func Load(path string) (*config.Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, _, err := codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	versioned, ok := obj.(*v1alpha1.ControllerConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("unexpected ControllerConfiguration type %T decoded from %s", obj, path)
+	}
+
+	v1alpha1.SetDefaults_ControllerConfiguration(versioned)
+	cfg := v1alpha1.Convert_v1alpha1_ControllerConfiguration_To_config_Config(versioned)
+
+	if err := validation.Validate(cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration in %s: %v", path, err)
+	}
+
+	return cfg, nil
+}
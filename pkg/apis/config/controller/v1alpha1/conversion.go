@@ -0,0 +1,36 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
+)
+
+// Convert_v1alpha1_ControllerConfiguration_To_config_Config converts the versioned, wire
+// ControllerConfiguration into the internal config.Config consumed by the controller.
+func Convert_v1alpha1_ControllerConfiguration_To_config_Config(in *ControllerConfiguration) *config.Config {
+	return &config.Config{
+		GCPProject:               in.GCPProject,
+		SslCertificateNamePrefix: in.SslCertificateNamePrefix,
+		RenewBefore:              in.RenewBefore.Duration,
+		MinRetiringAge:           in.MinRetiringAge.Duration,
+		QPS:                      in.QPS,
+		Burst:                    in.Burst,
+		NamespaceFilter:          in.NamespaceFilter,
+		FeatureGates:             in.FeatureGates,
+	}
+}
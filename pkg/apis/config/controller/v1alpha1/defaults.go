@@ -0,0 +1,54 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
+)
+
+const (
+	defaultRenewBefore    = 30 * 24 * time.Hour
+	defaultMinRetiringAge = 2 * time.Minute
+	defaultQPS            = 10.0
+	defaultBurst          = 20
+)
+
+// SetDefaults_ControllerConfiguration applies default values to any unset field of cfg.
+func SetDefaults_ControllerConfiguration(cfg *ControllerConfiguration) {
+	if cfg.RenewBefore.Duration == 0 {
+		cfg.RenewBefore = metav1.Duration{Duration: defaultRenewBefore}
+	}
+	if cfg.MinRetiringAge.Duration == 0 {
+		cfg.MinRetiringAge = metav1.Duration{Duration: defaultMinRetiringAge}
+	}
+	if cfg.QPS == 0 {
+		cfg.QPS = defaultQPS
+	}
+	if cfg.Burst == 0 {
+		cfg.Burst = defaultBurst
+	}
+	if cfg.FeatureGates == nil {
+		cfg.FeatureGates = map[string]bool{}
+	}
+	if _, set := cfg.FeatureGates[config.FeatureMultiCertOverlap]; !set {
+		cfg.FeatureGates[config.FeatureMultiCertOverlap] = true
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
+)
+
+func TestSetDefaultsFillsUnsetFields(t *testing.T) {
+	cfg := &ControllerConfiguration{}
+	SetDefaults_ControllerConfiguration(cfg)
+
+	if cfg.RenewBefore.Duration != defaultRenewBefore {
+		t.Errorf("RenewBefore = %s, want %s", cfg.RenewBefore.Duration, defaultRenewBefore)
+	}
+	if cfg.MinRetiringAge.Duration != defaultMinRetiringAge {
+		t.Errorf("MinRetiringAge = %s, want %s", cfg.MinRetiringAge.Duration, defaultMinRetiringAge)
+	}
+	if cfg.QPS != defaultQPS {
+		t.Errorf("QPS = %f, want %f", cfg.QPS, defaultQPS)
+	}
+	if cfg.Burst != defaultBurst {
+		t.Errorf("Burst = %d, want %d", cfg.Burst, defaultBurst)
+	}
+	if !cfg.FeatureGates[config.FeatureMultiCertOverlap] {
+		t.Errorf("FeatureGates[%s] = false, want true by default", config.FeatureMultiCertOverlap)
+	}
+}
+
+func TestSetDefaultsPreservesSetFields(t *testing.T) {
+	cfg := &ControllerConfiguration{QPS: 42, FeatureGates: map[string]bool{config.FeatureMultiCertOverlap: false}}
+	SetDefaults_ControllerConfiguration(cfg)
+
+	if cfg.QPS != 42 {
+		t.Errorf("QPS = %f, want 42", cfg.QPS)
+	}
+	if cfg.FeatureGates[config.FeatureMultiCertOverlap] {
+		t.Errorf("FeatureGates[%s] = true, want operator-set false to be preserved", config.FeatureMultiCertOverlap)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 is the v1alpha1 version of the managed-certificate-controller's
+// ControllerConfiguration API, loaded from the file passed via --config.
+// +k8s:deepcopy-gen=package
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControllerConfiguration configures the managed-certificate-controller.
+type ControllerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// GCPProject is the GCP project in which SslCertificate resources are managed. It has no
+	// default; a configuration that leaves it empty fails validation.
+	GCPProject string `json:"gcpProject,omitempty"`
+
+	// SslCertificateNamePrefix is prepended to the randomly generated names of managed
+	// SslCertificate resources.
+	SslCertificateNamePrefix string `json:"sslCertificateNamePrefix,omitempty"`
+
+	// RenewBefore is how long before a managed SslCertificate's expiry the expirer controller
+	// proactively triggers a rotation. Defaults to 720h (30 days).
+	RenewBefore metav1.Duration `json:"renewBefore,omitempty"`
+
+	// MinRetiringAge is how long a retiring SslCertificate is kept alive, once superseded by a
+	// promoted overlap rotation, before being deleted. Defaults to 2m.
+	MinRetiringAge metav1.Duration `json:"minRetiringAge,omitempty"`
+
+	// QPS is the maximum queries per second the controller issues against the Compute API.
+	QPS float32 `json:"qps,omitempty"`
+
+	// Burst is the maximum burst of queries the controller issues against the Compute API.
+	Burst int `json:"burst,omitempty"`
+
+	// NamespaceFilter restricts the controller to ManagedCertificates in the listed namespaces. An
+	// empty list means all namespaces are watched.
+	NamespaceFilter []string `json:"namespaceFilter,omitempty"`
+
+	// FeatureGates enables alpha and experimental controller behaviour, such as "MultiCertOverlap"
+	// for zero-downtime SslCertificate rotation.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1beta2 is the v1beta2 version of the ManagedCertificate API.
+// +k8s:deepcopy-gen=package
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ManagedCertificate represents a Google-managed or third-party-issued TLS certificate for one or
+// more domains, attached to a GKE Ingress by the managed-certificate-controller.
+type ManagedCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ManagedCertificateSpec   `json:"spec,omitempty"`
+	Status ManagedCertificateStatus `json:"status,omitempty"`
+}
+
+// ManagedCertificateSpec is the desired state of a ManagedCertificate.
+type ManagedCertificateSpec struct {
+	// Domains is the list of domains the certificate should cover.
+	Domains []string `json:"domains,omitempty"`
+
+	// IssuerRef selects the certificate-issuer backend that provisions this ManagedCertificate's
+	// certificate. Empty selects issuer.DefaultIssuerRef, the existing GCP-managed SslCertificate
+	// backend.
+	IssuerRef IssuerRef `json:"issuerRef,omitempty"`
+}
+
+// IssuerRef identifies the certificate-issuer backend - and, for backends such as cert-manager
+// that route to a specific issuer resource, that resource - used to provision a ManagedCertificate's
+// certificate.
+type IssuerRef struct {
+	// Name is the Issuer backend to use, e.g. "gcp" or "certmanager". The certmanager backend also
+	// passes Name on as the name of the cert-manager issuer resource it references; there is no
+	// separate field for that resource's name.
+	Name string `json:"name,omitempty"`
+
+	// Kind is the kind of the referenced issuer resource, meaningful only to backends that need it,
+	// such as cert-manager's Issuer/ClusterIssuer distinction.
+	Kind string `json:"kind,omitempty"`
+}
+
+// ManagedCertificateStatus is the observed state of a ManagedCertificate.
+type ManagedCertificateStatus struct {
+	// CertificateName is the name of the certificate, within its issuer backend, currently
+	// considered current.
+	CertificateName string `json:"certificateName,omitempty"`
+
+	// CertificateStatus is the provisioning status of CertificateName, as reported by its issuer
+	// backend.
+	CertificateStatus string `json:"certificateStatus,omitempty"`
+
+	// ExpireTime is the RFC3339-formatted expiry time of CertificateName, once active.
+	ExpireTime string `json:"expireTime,omitempty"`
+
+	// SslCertificateNames lists every SslCertificate currently backing this ManagedCertificate -
+	// both current and, while an overlap rotation is retiring the old one, the retiring
+	// SslCertificate too - so the ingress controller attaches all of them to the target proxy.
+	SslCertificateNames []string `json:"sslCertificateNames,omitempty"`
+
+	// DomainStatus maps each domain to its individual provisioning status, reported by issuer
+	// backends that expose per-domain status; nil for backends that don't.
+	DomainStatus map[string]string `json:"domainStatus,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ManagedCertificateList is a list of ManagedCertificates.
+type ManagedCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ManagedCertificate `json:"items"`
+}
@@ -35,6 +35,7 @@ const (
 	reasonDelete              = "Delete"
 	reasonTooManyCertificates = "TooManyCertificates"
 	reasonBackendError        = "BackendError"
+	reasonRenewalTriggered    = "RenewalTriggered"
 )
 
 type Event interface {
@@ -42,6 +43,7 @@ type Event interface {
 	Create(mcrt apisv1beta2.ManagedCertificate, sslCertificateName string)
 	Delete(mcrt apisv1beta2.ManagedCertificate, sslCertificateName string)
 	TooManyCertificates(mcrt apisv1beta2.ManagedCertificate, err error)
+	RenewalTriggered(mcrt apisv1beta2.ManagedCertificate, sslCertificateName string)
 }
 
 type eventImpl struct {
@@ -83,3 +85,9 @@ func (e eventImpl) Delete(mcrt apisv1beta2.ManagedCertificate, sslCertificateNam
 func (e eventImpl) TooManyCertificates(mcrt apisv1beta2.ManagedCertificate, err error) {
 	e.recorder.Event(&mcrt, v1.EventTypeWarning, reasonTooManyCertificates, err.Error())
 }
+
+// RenewalTriggered creates an event when the expirer controller proactively triggers a rotation ahead
+// of an SslCertificate's expiry.
+func (e eventImpl) RenewalTriggered(mcrt apisv1beta2.ManagedCertificate, sslCertificateName string) {
+	e.recorder.Eventf(&mcrt, v1.EventTypeNormal, reasonRenewalTriggered, "Proactively triggered renewal ahead of expiry for SslCertificate %s", sslCertificateName)
+}
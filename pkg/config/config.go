@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config defines configuration consumed by the managed-certificate-controller. It is
+// populated either from command-line flags, or from a versioned ControllerConfiguration file
+// decoded and converted by pkg/apis/config/controller/scheme.
+package config
+
+import "time"
+
+// FeatureMultiCertOverlap gates the zero-downtime SslCertificate rotation behaviour: when disabled,
+// the sync loop falls back to deleting a SslCertificate that fell out of sync and recreating it.
+const FeatureMultiCertOverlap = "MultiCertOverlap"
+
+// Config holds configuration for the managed-certificate-controller.
+type Config struct {
+	// GCPProject is the GCP project in which SslCertificate resources are managed.
+	GCPProject string
+
+	// SslCertificateNamePrefix is prepended to the randomly generated names of managed
+	// SslCertificate resources.
+	SslCertificateNamePrefix string
+
+	// RenewBefore is how long before a managed SslCertificate's expiry the expirer controller
+	// proactively triggers a rotation, as a safety net on top of Google-managed auto-renewal.
+	RenewBefore time.Duration
+
+	// MinRetiringAge is how long a retiring SslCertificate - superseded by a promoted overlap
+	// rotation - is kept alive before being deleted, giving the ingress controller time to actually
+	// attach the new SslCertificate to the target proxy before the old one stops serving traffic.
+	MinRetiringAge time.Duration
+
+	// QPS is the maximum queries per second the controller issues against the Compute API.
+	QPS float32
+
+	// Burst is the maximum burst of queries the controller issues against the Compute API.
+	Burst int
+
+	// NamespaceFilter restricts the controller to ManagedCertificates in the listed namespaces. An
+	// empty list means all namespaces are watched.
+	NamespaceFilter []string
+
+	// FeatureGates enables alpha and experimental controller behaviour.
+	FeatureGates map[string]bool
+}
+
+// FeatureEnabled reports whether the named feature gate is enabled.
+func (c *Config) FeatureEnabled(name string) bool {
+	return c.FeatureGates[name]
+}
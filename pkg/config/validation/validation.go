@@ -0,0 +1,45 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates a fully defaulted config.Config before it is handed to the controller.
+package validation
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
+)
+
+// Validate returns an error if cfg is not a valid configuration for the controller to run with.
+func Validate(cfg *config.Config) error {
+	if cfg.GCPProject == "" {
+		return fmt.Errorf("gcpProject must be set")
+	}
+	if cfg.RenewBefore <= 0 {
+		return fmt.Errorf("renewBefore must be a positive duration, got %s", cfg.RenewBefore)
+	}
+	if cfg.MinRetiringAge <= 0 {
+		return fmt.Errorf("minRetiringAge must be a positive duration, got %s", cfg.MinRetiringAge)
+	}
+	if cfg.QPS <= 0 {
+		return fmt.Errorf("qps must be positive, got %f", cfg.QPS)
+	}
+	if cfg.Burst <= 0 {
+		return fmt.Errorf("burst must be positive, got %d", cfg.Burst)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
+)
+
+func validConfig() *config.Config {
+	return &config.Config{
+		GCPProject:     "my-project",
+		RenewBefore:    24 * time.Hour,
+		MinRetiringAge: 2 * time.Minute,
+		QPS:            10,
+		Burst:          20,
+	}
+}
+
+func TestValidateAcceptsAValidConfig(t *testing.T) {
+	if err := Validate(validConfig()); err != nil {
+		t.Fatalf("Validate() returned error %v, want nil", err)
+	}
+}
+
+func TestValidateRejectsMissingGCPProject(t *testing.T) {
+	cfg := validConfig()
+	cfg.GCPProject = ""
+
+	if err := Validate(cfg); err == nil {
+		t.Fatal("Validate() succeeded with an empty gcpProject, want error")
+	}
+}
+
+func TestValidateRejectsNonPositiveRenewBefore(t *testing.T) {
+	cfg := validConfig()
+	cfg.RenewBefore = 0
+
+	if err := Validate(cfg); err == nil {
+		t.Fatal("Validate() succeeded with a non-positive renewBefore, want error")
+	}
+}
+
+func TestValidateRejectsNonPositiveMinRetiringAge(t *testing.T) {
+	cfg := validConfig()
+	cfg.MinRetiringAge = 0
+
+	if err := Validate(cfg); err == nil {
+		t.Fatal("Validate() succeeded with a non-positive minRetiringAge, want error")
+	}
+}
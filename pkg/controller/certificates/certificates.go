@@ -0,0 +1,74 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certificates converts between the desired state expressed by a ManagedCertificate and the
+// observed state of the certificate backing it, independent of which issuer backend produced that
+// certificate.
+package certificates
+
+import (
+	apisv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/networking.gke.io/v1beta2"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/issuer"
+)
+
+// DomainsEqual reports whether want and have contain the same set of domains, ignoring order. It is
+// shared by every issuer backend's Equal implementation.
+func DomainsEqual(want, have []string) bool {
+	if len(want) != len(have) {
+		return false
+	}
+
+	index := make(map[string]bool, len(have))
+	for _, domain := range have {
+		index[domain] = true
+	}
+	for _, domain := range want {
+		if !index[domain] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CopyStatus copies the status of cert onto mcrt. If retiring is non-nil, its name is published
+// alongside cert's so that the ingress controller can attach both to the target proxy - this is what
+// lets an old certificate keep serving traffic until its replacement has gone Active, instead of
+// leaving a gap where neither certificate covers the live domains.
+func CopyStatus(cert issuer.Certificate, retiring *issuer.Certificate, mcrt *apisv1beta2.ManagedCertificate,
+	config *config.Config) error {
+
+	mcrt.Status.CertificateName = cert.Name
+	mcrt.Status.CertificateStatus = string(cert.Status)
+	mcrt.Status.ExpireTime = cert.ExpireTime
+
+	sslCertificateNames := []string{cert.Name}
+	if retiring != nil {
+		sslCertificateNames = append(sslCertificateNames, retiring.Name)
+	}
+	mcrt.Status.SslCertificateNames = sslCertificateNames
+
+	if cert.DomainStatus != nil {
+		domainStatus := make(map[string]string, len(cert.DomainStatus))
+		for domain, status := range cert.DomainStatus {
+			domainStatus[domain] = status
+		}
+		mcrt.Status.DomainStatus = domainStatus
+	}
+
+	return nil
+}
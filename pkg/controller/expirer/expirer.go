@@ -0,0 +1,131 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package expirer periodically inspects, through each ManagedCertificate's issuer.Issuer backend,
+// the certificate(s) this controller manages and triggers a proactive rotation for any approaching
+// expiry. This is a SLO-driven safety net on top of each backend's own auto-renewal, covering
+// self-managed certificates and any certificate that fails to auto-renew in time.
+package expirer
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+
+	listersv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/clientgen/listers/networking.gke.io/v1beta2"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/clients/event"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/issuer"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/metrics"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/state"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/utils/types"
+)
+
+// Expirer watches the expiry time of the current SslCertificate of every ManagedCertificate tracked
+// in state and enqueues that ManagedCertificate for rotation once less than config.RenewBefore
+// remains until expiry. Pending and retiring SslCertificates are not checked.
+type Expirer interface {
+	// Run scans for expiring SslCertificates every resync, calling enqueue for each ManagedCertificate
+	// whose SslCertificate needs a proactive rotation. It blocks until ctx is done.
+	Run(ctx context.Context, enqueue func(types.CertId), resync time.Duration)
+}
+
+type expirerImpl struct {
+	config  *config.Config
+	event   event.Event
+	issuers map[string]issuer.Issuer
+	lister  listersv1beta2.ManagedCertificateLister
+	metrics metrics.Metrics
+	state   state.State
+}
+
+// New creates an Expirer. issuers maps a ManagedCertificate's spec.issuerRef to the backend that
+// provisions its certificate, the same map passed to sync.New; it must contain an entry for
+// issuer.DefaultIssuerRef.
+func New(config *config.Config, event event.Event, issuers map[string]issuer.Issuer,
+	lister listersv1beta2.ManagedCertificateLister, metrics metrics.Metrics, state state.State) Expirer {
+
+	return expirerImpl{
+		config:  config,
+		event:   event,
+		issuers: issuers,
+		lister:  lister,
+		metrics: metrics,
+		state:   state,
+	}
+}
+
+func (e expirerImpl) Run(ctx context.Context, enqueue func(types.CertId), resync time.Duration) {
+	wait.Until(func() { e.scanOnce(enqueue) }, resync, ctx.Done())
+}
+
+func (e expirerImpl) scanOnce(enqueue func(types.CertId)) {
+	for _, id := range e.state.List() {
+		if err := e.scanOne(id, enqueue); err != nil {
+			klog.Warningf("Failed to check expiry for ManagedCertificate %s: %v", id.String(), err)
+		}
+	}
+}
+
+func (e expirerImpl) scanOne(id types.CertId, enqueue func(types.CertId)) error {
+	sslCertificateName, err := e.state.GetSslCertificateName(id)
+	if err != nil {
+		return err
+	}
+
+	mcrt, err := e.lister.ManagedCertificates(id.Namespace).Get(id.Name)
+	if err != nil {
+		return err
+	}
+
+	recordedIssuerRef, err := e.state.GetIssuerRef(id)
+	if err != nil {
+		return err
+	}
+	iss := issuer.Resolve(e.issuers, issuer.ResolveRef(mcrt, recordedIssuerRef))
+
+	cert, err := iss.Get(sslCertificateName, mcrt)
+	if err != nil {
+		return err
+	}
+	if cert.ExpireTime == "" {
+		// A certificate not yet Active - or a backend that doesn't report an expiry - has nothing to
+		// proactively renew yet.
+		return nil
+	}
+
+	notAfter, err := time.Parse(time.RFC3339, cert.ExpireTime)
+	if err != nil {
+		return err
+	}
+
+	timeToExpiry := time.Until(notAfter)
+	e.metrics.ObserveSslCertificateTimeToExpiry(id, timeToExpiry)
+
+	if timeToExpiry < e.config.RenewBefore {
+		klog.Infof("SslCertificate %s for ManagedCertificate %s expires in %s, which is below the configured "+
+			"RenewBefore of %s, triggering a proactive rotation", sslCertificateName, id.String(), timeToExpiry, e.config.RenewBefore)
+		if err := e.state.SetForceRotationRequested(id); err != nil {
+			return err
+		}
+		e.event.RenewalTriggered(*mcrt, sslCertificateName)
+		enqueue(id)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certmanager adapts a cert-manager Certificate resource, and the Secret it produces, to the
+// issuer.Issuer interface, letting a ManagedCertificate be backed by an ACME or private CA issuer
+// instead of a GCP-managed SslCertificate.
+package certmanager
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmclientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	apisv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/networking.gke.io/v1beta2"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/certificates"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/issuer"
+)
+
+var errNoPEMBlock = errors.New("failed to decode PEM certificate from Secret")
+
+type certManagerIssuer struct {
+	cmClient cmclientset.Interface
+	client   kubernetes.Interface
+}
+
+// New creates an issuer.Issuer backed by cert-manager Certificate resources in the same namespace as
+// the ManagedCertificate, using cmClient to manage them and client to read the Secret cert-manager
+// writes the resulting key pair to.
+func New(cmClient cmclientset.Interface, client kubernetes.Interface) issuer.Issuer {
+	return certManagerIssuer{cmClient: cmClient, client: client}
+}
+
+func (c certManagerIssuer) Exists(name string, mcrt *apisv1beta2.ManagedCertificate) (bool, error) {
+	_, err := c.cmClient.CertmanagerV1().Certificates(mcrt.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c certManagerIssuer) Create(ctx context.Context, name string, mcrt apisv1beta2.ManagedCertificate) error {
+	cert := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: mcrt.Namespace,
+		},
+		Spec: cmapi.CertificateSpec{
+			SecretName: name,
+			DNSNames:   mcrt.Spec.Domains,
+			IssuerRef: cmmeta.ObjectReference{
+				Name: mcrt.Spec.IssuerRef.Name,
+				Kind: mcrt.Spec.IssuerRef.Kind,
+			},
+		},
+	}
+
+	_, err := c.cmClient.CertmanagerV1().Certificates(mcrt.Namespace).Create(ctx, cert, metav1.CreateOptions{})
+	return err
+}
+
+func (c certManagerIssuer) Get(name string, mcrt *apisv1beta2.ManagedCertificate) (*issuer.Certificate, error) {
+	cert, err := c.cmClient.CertmanagerV1().Certificates(mcrt.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	status := issuer.StatusProvisioning
+	for _, cond := range cert.Status.Conditions {
+		if cond.Type == cmapi.CertificateConditionReady {
+			if cond.Status == cmmeta.ConditionTrue {
+				status = issuer.StatusActive
+			} else if cond.Reason == "Failed" {
+				status = issuer.StatusFailed
+			}
+		}
+	}
+
+	result := &issuer.Certificate{
+		Name:    cert.Name,
+		Status:  status,
+		Domains: cert.Spec.DNSNames,
+	}
+
+	if status == issuer.StatusActive {
+		secret, err := c.client.CoreV1().Secrets(mcrt.Namespace).Get(context.Background(), cert.Spec.SecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		notAfter, err := parseNotAfter(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			return nil, err
+		}
+		result.ExpireTime = notAfter.Format(time.RFC3339)
+	}
+
+	return result, nil
+}
+
+func (c certManagerIssuer) Delete(ctx context.Context, name string, mcrt *apisv1beta2.ManagedCertificate) error {
+	return c.cmClient.CertmanagerV1().Certificates(mcrt.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (c certManagerIssuer) Equal(mcrt apisv1beta2.ManagedCertificate, cert issuer.Certificate) bool {
+	return certificates.DomainsEqual(mcrt.Spec.Domains, cert.Domains)
+}
+
+func parseNotAfter(pemCert []byte) (time.Time, error) {
+	block, _ := pem.Decode(pemCert)
+	if block == nil {
+		return time.Time{}, errNoPEMBlock
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cert.NotAfter, nil
+}
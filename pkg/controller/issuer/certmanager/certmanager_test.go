@@ -0,0 +1,84 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	"context"
+	"testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	apisv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/networking.gke.io/v1beta2"
+)
+
+func TestExistsReflectsCertManagerState(t *testing.T) {
+	mcrt := &apisv1beta2.ManagedCertificate{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "mcrt"}}
+	iss := New(cmfake.NewSimpleClientset(), k8sfake.NewSimpleClientset())
+
+	exists, err := iss.Exists("cert-1", mcrt)
+	if err != nil {
+		t.Fatalf("Exists() returned error %v", err)
+	}
+	if exists {
+		t.Fatal("Exists() = true, want false before the Certificate is created")
+	}
+
+	if err := iss.Create(context.Background(), "cert-1", *mcrt); err != nil {
+		t.Fatalf("Create() returned error %v", err)
+	}
+
+	exists, err = iss.Exists("cert-1", mcrt)
+	if err != nil {
+		t.Fatalf("Exists() returned error %v", err)
+	}
+	if !exists {
+		t.Fatal("Exists() = false, want true after the Certificate is created")
+	}
+}
+
+func TestGetReportsProvisioningUntilReady(t *testing.T) {
+	mcrt := &apisv1beta2.ManagedCertificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "mcrt"},
+	}
+	mcrt.Spec.Domains = []string{"example.com"}
+
+	cmClient := cmfake.NewSimpleClientset(&cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cert-1"},
+		Spec:       cmapi.CertificateSpec{SecretName: "cert-1", DNSNames: []string{"example.com"}},
+		Status: cmapi.CertificateStatus{
+			Conditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse},
+			},
+		},
+	})
+	iss := New(cmClient, k8sfake.NewSimpleClientset())
+
+	cert, err := iss.Get("cert-1", mcrt)
+	if err != nil {
+		t.Fatalf("Get() returned error %v", err)
+	}
+	if cert.Status != "Provisioning" {
+		t.Fatalf("Status = %v, want Provisioning while the Certificate is not Ready", cert.Status)
+	}
+	if !iss.Equal(*mcrt, *cert) {
+		t.Fatal("Equal() = false, want true: domains already match even though the certificate is still provisioning")
+	}
+}
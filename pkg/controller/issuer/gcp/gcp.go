@@ -0,0 +1,144 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gcp adapts the existing GCP SslCertificate-backed sslcertificatemanager.SslCertificateManager
+// to the issuer.Issuer interface. This is the default issuer backend.
+package gcp
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+
+	apisv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/networking.gke.io/v1beta2"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/certificates"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/issuer"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/sslcertificatemanager"
+)
+
+var errNoPEMBlock = errors.New("failed to decode PEM certificate")
+
+const (
+	statusActive = "ACTIVE"
+)
+
+var failedStatuses = map[string]bool{
+	"RENEWAL_FAILED":       true,
+	"FAILED_NOT_VISIBLE":   true,
+	"FAILED_CAA_CHECKING":  true,
+	"FAILED_CAA_FORBIDDEN": true,
+}
+
+type gcpIssuer struct {
+	ssl sslcertificatemanager.SslCertificateManager
+}
+
+// New adapts ssl, the existing GCP SslCertificate manager, to the issuer.Issuer interface.
+func New(ssl sslcertificatemanager.SslCertificateManager) issuer.Issuer {
+	return gcpIssuer{ssl: ssl}
+}
+
+func (g gcpIssuer) Exists(name string, mcrt *apisv1beta2.ManagedCertificate) (bool, error) {
+	return g.ssl.Exists(name, mcrt)
+}
+
+func (g gcpIssuer) Create(ctx context.Context, name string, mcrt apisv1beta2.ManagedCertificate) error {
+	return g.ssl.Create(ctx, name, mcrt)
+}
+
+func (g gcpIssuer) Get(name string, mcrt *apisv1beta2.ManagedCertificate) (*issuer.Certificate, error) {
+	sslCert, err := g.ssl.Get(name, mcrt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Managed is nil for a self-managed SslCertificate - its domains and expiry live elsewhere
+	// (mcrt.Spec.Domains and the PEM-encoded Certificate field respectively), so build its
+	// issuer.Certificate separately rather than reading through a nil pointer.
+	if sslCert.Managed == nil {
+		return g.selfManagedCertificate(sslCert, mcrt)
+	}
+
+	status := issuer.StatusProvisioning
+	if sslCert.Managed.Status == statusActive {
+		status = issuer.StatusActive
+	} else if failedStatuses[sslCert.Managed.Status] {
+		status = issuer.StatusFailed
+	}
+
+	domainStatus := make(map[string]string, len(sslCert.Managed.DomainStatus))
+	for domain, s := range sslCert.Managed.DomainStatus {
+		domainStatus[domain] = s
+	}
+
+	return &issuer.Certificate{
+		Name:         sslCert.Name,
+		Status:       status,
+		ExpireTime:   sslCert.Managed.ExpireTime,
+		Domains:      sslCert.Managed.Domains,
+		DomainStatus: domainStatus,
+	}, nil
+}
+
+func (g gcpIssuer) Delete(ctx context.Context, name string, mcrt *apisv1beta2.ManagedCertificate) error {
+	return g.ssl.Delete(ctx, name, mcrt)
+}
+
+func (g gcpIssuer) Equal(mcrt apisv1beta2.ManagedCertificate, cert issuer.Certificate) bool {
+	return certificates.DomainsEqual(mcrt.Spec.Domains, cert.Domains)
+}
+
+// selfManagedCertificate builds the issuer.Certificate for a self-managed SslCertificate: it is
+// always considered Active by the Compute API, covers mcrt.Spec.Domains, and reports its expiry by
+// parsing the PEM certificate chain the API serves back, the same way the expirer controller used to
+// do it directly.
+func (g gcpIssuer) selfManagedCertificate(sslCert *compute.SslCertificate,
+	mcrt *apisv1beta2.ManagedCertificate) (*issuer.Certificate, error) {
+
+	expireTime := ""
+	if sslCert.Certificate != "" {
+		notAfter, err := parseNotAfter(sslCert.Certificate)
+		if err != nil {
+			return nil, err
+		}
+		expireTime = notAfter.Format(time.RFC3339)
+	}
+
+	return &issuer.Certificate{
+		Name:       sslCert.Name,
+		Status:     issuer.StatusActive,
+		ExpireTime: expireTime,
+		Domains:    mcrt.Spec.Domains,
+	}, nil
+}
+
+func parseNotAfter(pemCerts string) (time.Time, error) {
+	block, _ := pem.Decode([]byte(pemCerts))
+	if block == nil {
+		return time.Time{}, errNoPEMBlock
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cert.NotAfter, nil
+}
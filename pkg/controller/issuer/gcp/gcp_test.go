@@ -0,0 +1,145 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/networking.gke.io/v1beta2"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/issuer"
+)
+
+// fakeSslCertificateManager is a minimal sslcertificatemanager.SslCertificateManager stand-in that
+// returns a fixed SslCertificate from Get.
+type fakeSslCertificateManager struct {
+	sslCert *compute.SslCertificate
+}
+
+func (f fakeSslCertificateManager) Exists(name string, mcrt *apisv1beta2.ManagedCertificate) (bool, error) {
+	return true, nil
+}
+
+func (f fakeSslCertificateManager) Create(ctx context.Context, name string, mcrt apisv1beta2.ManagedCertificate) error {
+	return nil
+}
+
+func (f fakeSslCertificateManager) Get(name string, mcrt *apisv1beta2.ManagedCertificate) (*compute.SslCertificate, error) {
+	return f.sslCert, nil
+}
+
+func (f fakeSslCertificateManager) Delete(ctx context.Context, name string, mcrt *apisv1beta2.ManagedCertificate) error {
+	return nil
+}
+
+func TestGetHandlesSelfManagedSslCertificate(t *testing.T) {
+	mcrt := &apisv1beta2.ManagedCertificate{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "mcrt"}}
+	mcrt.Spec.Domains = []string{"example.com"}
+
+	iss := New(fakeSslCertificateManager{sslCert: &compute.SslCertificate{Name: "cert-1"}})
+
+	cert, err := iss.Get("cert-1", mcrt)
+	if err != nil {
+		t.Fatalf("Get() returned error %v, want nil for a self-managed SslCertificate with Managed == nil", err)
+	}
+	if cert.Status != issuer.StatusActive {
+		t.Errorf("Status = %v, want Active for a self-managed SslCertificate", cert.Status)
+	}
+}
+
+func TestGetReportsManagedStatus(t *testing.T) {
+	mcrt := &apisv1beta2.ManagedCertificate{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "mcrt"}}
+
+	iss := New(fakeSslCertificateManager{sslCert: &compute.SslCertificate{
+		Name: "cert-1",
+		Managed: &compute.SslCertificateManagedSslCertificate{
+			Status:  statusActive,
+			Domains: []string{"example.com"},
+		},
+	}})
+
+	cert, err := iss.Get("cert-1", mcrt)
+	if err != nil {
+		t.Fatalf("Get() returned error %v", err)
+	}
+	if cert.Status != issuer.StatusActive {
+		t.Errorf("Status = %v, want Active", cert.Status)
+	}
+}
+
+func TestGetParsesExpiryFromSelfManagedCertificate(t *testing.T) {
+	mcrt := &apisv1beta2.ManagedCertificate{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "mcrt"}}
+
+	notAfter := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+	iss := New(fakeSslCertificateManager{sslCert: &compute.SslCertificate{
+		Name:        "cert-1",
+		Certificate: pemCertificateExpiringAt(t, notAfter),
+	}})
+
+	cert, err := iss.Get("cert-1", mcrt)
+	if err != nil {
+		t.Fatalf("Get() returned error %v", err)
+	}
+	if want := notAfter.Format(time.RFC3339); cert.ExpireTime != want {
+		t.Errorf("ExpireTime = %q, want %q", cert.ExpireTime, want)
+	}
+}
+
+func TestGetRejectsGarbageSelfManagedCertificate(t *testing.T) {
+	mcrt := &apisv1beta2.ManagedCertificate{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "mcrt"}}
+
+	iss := New(fakeSslCertificateManager{sslCert: &compute.SslCertificate{Name: "cert-1", Certificate: "not a certificate"}})
+
+	if _, err := iss.Get("cert-1", mcrt); err == nil {
+		t.Fatal("Get() succeeded on a garbage self-managed certificate, want error")
+	}
+}
+
+// pemCertificateExpiringAt generates a self-signed certificate whose NotAfter is notAfter, PEM
+// encoded the way the Compute API serves self-managed SslCertificate.Certificate back.
+func pemCertificateExpiringAt(t *testing.T, notAfter time.Time) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
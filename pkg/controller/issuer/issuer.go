@@ -0,0 +1,107 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package issuer defines the narrow interface implemented by certificate-issuer backends, letting
+// syncImpl.ensureSslCertificate provision the certificate backing a ManagedCertificate from GCP-managed
+// SslCertificates or from a third-party issuer such as cert-manager, selected per-ManagedCertificate via
+// spec.issuerRef.
+package issuer
+
+import (
+	"context"
+
+	"k8s.io/klog"
+
+	apisv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/networking.gke.io/v1beta2"
+)
+
+// DefaultIssuerRef is used for ManagedCertificates that do not set spec.issuerRef, preserving the
+// existing GCP-managed-cert behaviour. Annotations are not consulted.
+const DefaultIssuerRef = "gcp"
+
+// Status is the lifecycle status of a Certificate, independent of which backend provisioned it.
+type Status string
+
+const (
+	StatusProvisioning Status = "Provisioning"
+	StatusActive       Status = "Active"
+	StatusFailed       Status = "Failed"
+)
+
+// Certificate is the backend-agnostic view of a provisioned certificate, translated from either a
+// GCP SslCertificate or a cert-manager Certificate and the Secret it produces.
+type Certificate struct {
+	// Name identifies the certificate within its backend.
+	Name string
+
+	Status Status
+
+	// ExpireTime is RFC3339-formatted, matching the GCP SslCertificate representation.
+	ExpireTime string
+
+	// Domains are the domains this certificate currently covers.
+	Domains []string
+
+	// DomainStatus maps each domain to its individual provisioning status, for backends that expose
+	// per-domain status (GCP-managed certs); nil for backends that don't.
+	DomainStatus map[string]string
+}
+
+// Issuer provisions and tracks the certificate backing a single ManagedCertificate.
+type Issuer interface {
+	// Exists reports whether a certificate named name already exists for mcrt.
+	Exists(name string, mcrt *apisv1beta2.ManagedCertificate) (bool, error)
+
+	// Create starts provisioning a certificate named name for mcrt.
+	Create(ctx context.Context, name string, mcrt apisv1beta2.ManagedCertificate) error
+
+	// Get fetches the current state of the certificate named name for mcrt.
+	Get(name string, mcrt *apisv1beta2.ManagedCertificate) (*Certificate, error)
+
+	// Delete removes the certificate named name for mcrt.
+	Delete(ctx context.Context, name string, mcrt *apisv1beta2.ManagedCertificate) error
+
+	// Equal reports whether cert already satisfies the domains requested by mcrt.
+	Equal(mcrt apisv1beta2.ManagedCertificate, cert Certificate) bool
+}
+
+// ResolveRef returns the name of the Issuer backend to use: mcrt's spec.issuerRef if mcrt is
+// non-nil and requests one, otherwise recordedIssuerRef - the issuerRef previously recorded in
+// state, so that a ManagedCertificate that has since been deleted, or hasn't set spec.issuerRef,
+// still routes to the backend that actually provisioned its certificate(s). Falls back to
+// DefaultIssuerRef if neither is set.
+func ResolveRef(mcrt *apisv1beta2.ManagedCertificate, recordedIssuerRef string) string {
+	if mcrt != nil && mcrt.Spec.IssuerRef.Name != "" {
+		return mcrt.Spec.IssuerRef.Name
+	}
+
+	if recordedIssuerRef != "" {
+		return recordedIssuerRef
+	}
+
+	return DefaultIssuerRef
+}
+
+// Resolve picks the Issuer backend named name out of issuers, falling back to DefaultIssuerRef if
+// name is not a known backend.
+func Resolve(issuers map[string]Issuer, name string) Issuer {
+	if iss, exists := issuers[name]; exists {
+		return iss
+	}
+
+	klog.Warningf("Unknown issuerRef %q, falling back to %s", name, DefaultIssuerRef)
+	return issuers[DefaultIssuerRef]
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines and registers Prometheus metrics emitted by the
+// managed-certificate-controller.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/utils/types"
+)
+
+const subsystem = "managed_certificate_controller"
+
+// Metrics exposes Prometheus metrics for the managed-certificate-controller.
+type Metrics interface {
+	// ObserveSslCertificateCreationLatency reports the time elapsed between a ManagedCertificate
+	// being created and its first SslCertificate being observed.
+	ObserveSslCertificateCreationLatency(createdAt time.Time)
+
+	// ObserveSslCertificateTimeToExpiry reports, for id, how much time remains until its current
+	// SslCertificate expires.
+	ObserveSslCertificateTimeToExpiry(id types.CertId, timeToExpiry time.Duration)
+}
+
+type metricsImpl struct {
+	sslCertificateCreationLatency prometheus.Histogram
+	sslCertificateTimeToExpiry    *prometheus.GaugeVec
+}
+
+// New creates and registers the managed-certificate-controller's Prometheus metrics.
+func New() Metrics {
+	m := &metricsImpl{
+		sslCertificateCreationLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "ssl_certificate_creation_latency_seconds",
+			Help:      "Latency between a ManagedCertificate being created and its backing SslCertificate being created, in seconds.",
+		}),
+		sslCertificateTimeToExpiry: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: subsystem,
+			Name:      "ssl_certificate_time_to_expiry_seconds",
+			Help:      "Time remaining until the SslCertificate backing a ManagedCertificate expires, in seconds.",
+		}, []string{"namespace", "name"}),
+	}
+
+	prometheus.MustRegister(m.sslCertificateCreationLatency)
+	prometheus.MustRegister(m.sslCertificateTimeToExpiry)
+
+	return m
+}
+
+func (m *metricsImpl) ObserveSslCertificateCreationLatency(createdAt time.Time) {
+	m.sslCertificateCreationLatency.Observe(time.Since(createdAt).Seconds())
+}
+
+func (m *metricsImpl) ObserveSslCertificateTimeToExpiry(id types.CertId, timeToExpiry time.Duration) {
+	m.sslCertificateTimeToExpiry.WithLabelValues(id.Namespace, id.Name).Set(timeToExpiry.Seconds())
+}
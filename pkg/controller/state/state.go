@@ -0,0 +1,326 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package state tracks, for every ManagedCertificate, which SslCertificate resources are
+// currently associated with it.
+package state
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/errors"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/utils/types"
+)
+
+// Entry is the state tracked for a single ManagedCertificate.
+type Entry struct {
+	// SslCertificateName is the name of the SslCertificate currently serving this ManagedCertificate.
+	SslCertificateName string
+
+	// PendingSslCertificateName is the name of a new SslCertificate created to replace
+	// SslCertificateName while a rotation - triggered by a domain change or a renewal - is in
+	// progress. Empty if no rotation is underway.
+	PendingSslCertificateName string
+
+	// RetiringSslCertificateName is the name of an SslCertificate that used to serve this
+	// ManagedCertificate and is kept alive, still published in status, until it is safe to
+	// soft-delete. Empty once retirement completes.
+	RetiringSslCertificateName string
+
+	// RetiringSince is when RetiringSslCertificateName was superseded, via PromotePending. Used to
+	// gate deletion on a minimum dwell time instead of on "one more reconcile happened", which
+	// would let the overlap window this feature exists to create collapse to near-zero. The zero
+	// Time while RetiringSslCertificateName is empty.
+	RetiringSince time.Time
+
+	SoftDeleted                    bool
+	Excluded                       bool
+	SslCertificateCreationReported bool
+
+	// ForceRotationRequested is set by the expirer controller to force ensureSslCertificate to
+	// start an overlap rotation even though domains have not changed, because the current
+	// certificate is approaching expiry.
+	ForceRotationRequested bool
+
+	// IssuerRef is the name of the Issuer backend that provisioned the tracked SslCertificate(s),
+	// recorded so that teardown can route deletion to the right backend even after the
+	// ManagedCertificate that requested it is gone.
+	IssuerRef string
+}
+
+// State tracks, for every ManagedCertificate, which SslCertificate resources are currently
+// associated with it.
+type State interface {
+	// List returns the ids of all ManagedCertificates currently tracked.
+	List() []types.CertId
+
+	Delete(id types.CertId)
+
+	GetSslCertificateName(id types.CertId) (string, error)
+	SetSslCertificateName(id types.CertId, sslCertificateName string)
+
+	// GetSslCertificateNames returns the current, pending (if a rotation is underway) and
+	// retiring (if an old SslCertificate is still being drained) SslCertificate names for id.
+	GetSslCertificateNames(id types.CertId) (current, pending, retiring string, err error)
+
+	// StartRotation records sslCertificateName as the pending replacement for the current
+	// SslCertificate of id, without yet touching the current one.
+	StartRotation(id types.CertId, sslCertificateName string)
+
+	// PromotePending makes the pending SslCertificate current, and moves the previous current
+	// SslCertificate to retiring so it can be soft-deleted once it is no longer referenced.
+	PromotePending(id types.CertId) error
+
+	// ClearRetiring drops the retiring SslCertificate name once it has been deleted.
+	ClearRetiring(id types.CertId)
+
+	// RetiringSince returns when id's retiring SslCertificate was superseded, or the zero Time if
+	// none is retiring.
+	RetiringSince(id types.CertId) (time.Time, error)
+
+	IsExcludedFromSLO(id types.CertId) (bool, error)
+	SetExcludedFromSLO(id types.CertId)
+
+	IsSslCertificateCreationReported(id types.CertId) (bool, error)
+	SetSslCertificateCreationReported(id types.CertId) error
+
+	IsSoftDeleted(id types.CertId) (bool, error)
+	SetSoftDeleted(id types.CertId) error
+
+	// IsForceRotationRequested reports whether a forced rotation - requested by the expirer
+	// controller ahead of expiry - is pending for id.
+	IsForceRotationRequested(id types.CertId) (bool, error)
+	// SetForceRotationRequested marks a forced rotation as pending for id.
+	SetForceRotationRequested(id types.CertId) error
+	// ClearForceRotationRequested drops a pending forced rotation once ensureSslCertificate has
+	// started it.
+	ClearForceRotationRequested(id types.CertId)
+
+	// GetIssuerRef returns the Issuer backend name recorded for id, or "" if none was recorded yet.
+	GetIssuerRef(id types.CertId) (string, error)
+	// SetIssuerRef records the Issuer backend name that provisioned id's SslCertificate(s).
+	SetIssuerRef(id types.CertId, issuerRef string)
+}
+
+type stateImpl struct {
+	mu      sync.Mutex
+	entries map[types.CertId]Entry
+}
+
+// New creates a new, empty State.
+func New() State {
+	return &stateImpl{
+		entries: make(map[types.CertId]Entry),
+	}
+}
+
+func (s *stateImpl) get(id types.CertId) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.entries[id]
+	if !exists {
+		return Entry{}, errors.ErrManagedCertificateNotFound
+	}
+	return entry, nil
+}
+
+func (s *stateImpl) set(id types.CertId, entry Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[id] = entry
+}
+
+func (s *stateImpl) List() []types.CertId {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]types.CertId, 0, len(s.entries))
+	for id := range s.entries {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (s *stateImpl) Delete(id types.CertId) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+}
+
+func (s *stateImpl) GetSslCertificateName(id types.CertId) (string, error) {
+	entry, err := s.get(id)
+	if err != nil {
+		return "", err
+	}
+	return entry.SslCertificateName, nil
+}
+
+func (s *stateImpl) SetSslCertificateName(id types.CertId, sslCertificateName string) {
+	entry, _ := s.get(id)
+	entry.SslCertificateName = sslCertificateName
+	s.set(id, entry)
+}
+
+func (s *stateImpl) GetSslCertificateNames(id types.CertId) (string, string, string, error) {
+	entry, err := s.get(id)
+	if err != nil {
+		return "", "", "", err
+	}
+	return entry.SslCertificateName, entry.PendingSslCertificateName, entry.RetiringSslCertificateName, nil
+}
+
+func (s *stateImpl) StartRotation(id types.CertId, sslCertificateName string) {
+	entry, _ := s.get(id)
+
+	klog.Infof("Start rotation for %s: pending SslCertificate %s alongside current %s", id.String(),
+		sslCertificateName, entry.SslCertificateName)
+	entry.PendingSslCertificateName = sslCertificateName
+	s.set(id, entry)
+}
+
+func (s *stateImpl) PromotePending(id types.CertId) error {
+	entry, err := s.get(id)
+	if err != nil {
+		return err
+	}
+
+	if entry.PendingSslCertificateName == "" {
+		return fmt.Errorf("no SslCertificate rotation pending for %s", id.String())
+	}
+
+	klog.Infof("Promote pending SslCertificate %s to current for %s, retiring %s", entry.PendingSslCertificateName,
+		id.String(), entry.SslCertificateName)
+	entry.RetiringSslCertificateName = entry.SslCertificateName
+	entry.RetiringSince = time.Now()
+	entry.SslCertificateName = entry.PendingSslCertificateName
+	entry.PendingSslCertificateName = ""
+	s.set(id, entry)
+	return nil
+}
+
+func (s *stateImpl) ClearRetiring(id types.CertId) {
+	entry, err := s.get(id)
+	if err != nil {
+		return
+	}
+	entry.RetiringSslCertificateName = ""
+	entry.RetiringSince = time.Time{}
+	s.set(id, entry)
+}
+
+func (s *stateImpl) RetiringSince(id types.CertId) (time.Time, error) {
+	entry, err := s.get(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return entry.RetiringSince, nil
+}
+
+func (s *stateImpl) IsExcludedFromSLO(id types.CertId) (bool, error) {
+	entry, err := s.get(id)
+	if err != nil {
+		return false, err
+	}
+	return entry.Excluded, nil
+}
+
+func (s *stateImpl) SetExcludedFromSLO(id types.CertId) {
+	entry, _ := s.get(id)
+	entry.Excluded = true
+	s.set(id, entry)
+}
+
+func (s *stateImpl) IsSslCertificateCreationReported(id types.CertId) (bool, error) {
+	entry, err := s.get(id)
+	if err != nil {
+		return false, err
+	}
+	return entry.SslCertificateCreationReported, nil
+}
+
+func (s *stateImpl) SetSslCertificateCreationReported(id types.CertId) error {
+	entry, err := s.get(id)
+	if err != nil {
+		return err
+	}
+	entry.SslCertificateCreationReported = true
+	s.set(id, entry)
+	return nil
+}
+
+func (s *stateImpl) IsSoftDeleted(id types.CertId) (bool, error) {
+	entry, err := s.get(id)
+	if err != nil {
+		return false, err
+	}
+	return entry.SoftDeleted, nil
+}
+
+func (s *stateImpl) SetSoftDeleted(id types.CertId) error {
+	entry, err := s.get(id)
+	if err != nil {
+		return err
+	}
+	entry.SoftDeleted = true
+	s.set(id, entry)
+	return nil
+}
+
+func (s *stateImpl) IsForceRotationRequested(id types.CertId) (bool, error) {
+	entry, err := s.get(id)
+	if err != nil {
+		return false, err
+	}
+	return entry.ForceRotationRequested, nil
+}
+
+func (s *stateImpl) SetForceRotationRequested(id types.CertId) error {
+	entry, err := s.get(id)
+	if err != nil {
+		return err
+	}
+	entry.ForceRotationRequested = true
+	s.set(id, entry)
+	return nil
+}
+
+func (s *stateImpl) ClearForceRotationRequested(id types.CertId) {
+	entry, err := s.get(id)
+	if err != nil {
+		return
+	}
+	entry.ForceRotationRequested = false
+	s.set(id, entry)
+}
+
+func (s *stateImpl) GetIssuerRef(id types.CertId) (string, error) {
+	entry, err := s.get(id)
+	if err != nil {
+		return "", err
+	}
+	return entry.IssuerRef, nil
+}
+
+func (s *stateImpl) SetIssuerRef(id types.CertId, issuerRef string) {
+	entry, _ := s.get(id)
+	entry.IssuerRef = issuerRef
+	s.set(id, entry)
+}
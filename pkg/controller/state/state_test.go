@@ -0,0 +1,155 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/utils/types"
+)
+
+var id = types.CertId{Namespace: "default", Name: "mcrt"}
+
+func TestOverlapStateMachine(t *testing.T) {
+	s := New()
+
+	s.SetSslCertificateName(id, "cert-1")
+
+	current, pending, retiring, err := s.GetSslCertificateNames(id)
+	if err != nil {
+		t.Fatalf("GetSslCertificateNames() returned error %v", err)
+	}
+	if current != "cert-1" || pending != "" || retiring != "" {
+		t.Fatalf("GetSslCertificateNames() = (%q, %q, %q), want (cert-1, \"\", \"\")", current, pending, retiring)
+	}
+
+	s.StartRotation(id, "cert-2")
+
+	current, pending, retiring, err = s.GetSslCertificateNames(id)
+	if err != nil {
+		t.Fatalf("GetSslCertificateNames() returned error %v", err)
+	}
+	if current != "cert-1" || pending != "cert-2" || retiring != "" {
+		t.Fatalf("GetSslCertificateNames() = (%q, %q, %q), want (cert-1, cert-2, \"\")", current, pending, retiring)
+	}
+
+	if err := s.PromotePending(id); err != nil {
+		t.Fatalf("PromotePending() returned error %v", err)
+	}
+
+	current, pending, retiring, err = s.GetSslCertificateNames(id)
+	if err != nil {
+		t.Fatalf("GetSslCertificateNames() returned error %v", err)
+	}
+	if current != "cert-2" || pending != "" || retiring != "cert-1" {
+		t.Fatalf("GetSslCertificateNames() = (%q, %q, %q), want (cert-2, \"\", cert-1)", current, pending, retiring)
+	}
+
+	if retiringSince, err := s.RetiringSince(id); err != nil {
+		t.Fatalf("RetiringSince() returned error %v", err)
+	} else if retiringSince.IsZero() {
+		t.Fatal("RetiringSince() = zero Time, want non-zero once PromotePending has run")
+	}
+
+	s.ClearRetiring(id)
+
+	current, pending, retiring, err = s.GetSslCertificateNames(id)
+	if err != nil {
+		t.Fatalf("GetSslCertificateNames() returned error %v", err)
+	}
+	if current != "cert-2" || pending != "" || retiring != "" {
+		t.Fatalf("GetSslCertificateNames() = (%q, %q, %q), want (cert-2, \"\", \"\")", current, pending, retiring)
+	}
+
+	if retiringSince, err := s.RetiringSince(id); err != nil {
+		t.Fatalf("RetiringSince() returned error %v", err)
+	} else if !retiringSince.IsZero() {
+		t.Fatalf("RetiringSince() = %s, want zero Time once ClearRetiring has run", retiringSince)
+	}
+}
+
+func TestPromotePendingWithoutRotationFails(t *testing.T) {
+	s := New()
+	s.SetSslCertificateName(id, "cert-1")
+
+	if err := s.PromotePending(id); err == nil {
+		t.Fatal("PromotePending() succeeded, want error because no rotation was started")
+	}
+}
+
+func TestForceRotationRequested(t *testing.T) {
+	s := New()
+	s.SetSslCertificateName(id, "cert-1")
+
+	if requested, err := s.IsForceRotationRequested(id); err != nil {
+		t.Fatalf("IsForceRotationRequested() returned error %v", err)
+	} else if requested {
+		t.Fatal("IsForceRotationRequested() = true, want false before SetForceRotationRequested()")
+	}
+
+	if err := s.SetForceRotationRequested(id); err != nil {
+		t.Fatalf("SetForceRotationRequested() returned error %v", err)
+	}
+
+	if requested, err := s.IsForceRotationRequested(id); err != nil {
+		t.Fatalf("IsForceRotationRequested() returned error %v", err)
+	} else if !requested {
+		t.Fatal("IsForceRotationRequested() = false, want true after SetForceRotationRequested()")
+	}
+
+	s.ClearForceRotationRequested(id)
+
+	if requested, err := s.IsForceRotationRequested(id); err != nil {
+		t.Fatalf("IsForceRotationRequested() returned error %v", err)
+	} else if requested {
+		t.Fatal("IsForceRotationRequested() = true, want false after ClearForceRotationRequested()")
+	}
+}
+
+func TestIssuerRef(t *testing.T) {
+	s := New()
+	s.SetSslCertificateName(id, "cert-1")
+
+	if issuerRef, err := s.GetIssuerRef(id); err != nil {
+		t.Fatalf("GetIssuerRef() returned error %v", err)
+	} else if issuerRef != "" {
+		t.Fatalf("GetIssuerRef() = %q, want \"\" before SetIssuerRef()", issuerRef)
+	}
+
+	s.SetIssuerRef(id, "certmanager")
+
+	if issuerRef, err := s.GetIssuerRef(id); err != nil {
+		t.Fatalf("GetIssuerRef() returned error %v", err)
+	} else if issuerRef != "certmanager" {
+		t.Fatalf("GetIssuerRef() = %q, want certmanager", issuerRef)
+	}
+}
+
+func TestListReflectsTrackedIds(t *testing.T) {
+	s := New()
+	s.SetSslCertificateName(id, "cert-1")
+
+	ids := s.List()
+	if len(ids) != 1 || ids[0] != id {
+		t.Fatalf("List() = %v, want [%v]", ids, id)
+	}
+
+	s.Delete(id)
+	if ids := s.List(); len(ids) != 0 {
+		t.Fatalf("List() = %v, want empty after Delete()", ids)
+	}
+}
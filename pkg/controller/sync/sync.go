@@ -21,7 +21,6 @@ import (
 	"context"
 	"time"
 
-	compute "google.golang.org/api/compute/v0.beta"
 	"k8s.io/klog"
 
 	apisv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/networking.gke.io/v1beta2"
@@ -30,8 +29,8 @@ import (
 	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
 	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/certificates"
 	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/errors"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/issuer"
 	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/metrics"
-	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/sslcertificatemanager"
 	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/state"
 	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/utils/http"
 	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/utils/random"
@@ -45,39 +44,40 @@ type Sync interface {
 type syncImpl struct {
 	client  clientsetv1beta2.NetworkingV1beta2Interface
 	config  *config.Config
+	issuers map[string]issuer.Issuer
 	lister  listersv1beta2.ManagedCertificateLister
 	metrics metrics.Metrics
 	random  random.Random
-	ssl     sslcertificatemanager.SslCertificateManager
 	state   state.State
 }
 
-func New(client clientsetv1beta2.NetworkingV1beta2Interface, config *config.Config, lister listersv1beta2.ManagedCertificateLister,
-	metrics metrics.Metrics, random random.Random, ssl sslcertificatemanager.SslCertificateManager, state state.State) Sync {
+// New creates a Sync. issuers maps a ManagedCertificate's spec.issuerRef to the backend that
+// provisions its certificate; it must contain an entry for issuer.DefaultIssuerRef.
+func New(client clientsetv1beta2.NetworkingV1beta2Interface, config *config.Config, issuers map[string]issuer.Issuer,
+	lister listersv1beta2.ManagedCertificateLister, metrics metrics.Metrics, random random.Random, state state.State) Sync {
+
 	return syncImpl{
 		client:  client,
 		config:  config,
+		issuers: issuers,
 		lister:  lister,
 		metrics: metrics,
 		random:  random,
-		ssl:     ssl,
 		state:   state,
 	}
 }
 
-func (s syncImpl) ensureSslCertificateName(id types.CertId) (string, error) {
-	if sslCertificateName, err := s.state.GetSslCertificateName(id); err == nil {
-		return sslCertificateName, nil
-	}
-
-	sslCertificateName, err := s.random.Name()
-	if err != nil {
-		return "", err
-	}
+// resolveIssuerRef returns the name of the Issuer backend to use for id, see issuer.ResolveRef -
+// falling back to the issuerRef previously recorded in state for id, so that teardown still routes
+// to the backend that actually provisioned the resource once mcrt is gone.
+func (s syncImpl) resolveIssuerRef(mcrt *apisv1beta2.ManagedCertificate, id types.CertId) string {
+	recordedIssuerRef, _ := s.state.GetIssuerRef(id)
+	return issuer.ResolveRef(mcrt, recordedIssuerRef)
+}
 
-	klog.Infof("Add to state SslCertificate name %s for ManagedCertificate %s", sslCertificateName, id.String())
-	s.state.SetSslCertificateName(id, sslCertificateName)
-	return sslCertificateName, nil
+// resolveIssuer picks the Issuer backend for id, see resolveIssuerRef.
+func (s syncImpl) resolveIssuer(mcrt *apisv1beta2.ManagedCertificate, id types.CertId) issuer.Issuer {
+	return issuer.Resolve(s.issuers, s.resolveIssuerRef(mcrt, id))
 }
 
 func (s syncImpl) observeSslCertificateCreationLatencyIfNeeded(sslCertificateName string, id types.CertId, mcrt apisv1beta2.ManagedCertificate) error {
@@ -120,7 +120,7 @@ func (s syncImpl) deleteSslCertificate(ctx context.Context, mcrt *apisv1beta2.Ma
 	}
 
 	klog.Infof("Delete SslCertificate %s for ManagedCertificate %s", sslCertificateName, id.String())
-	if err := http.IgnoreNotFound(s.ssl.Delete(ctx, sslCertificateName, mcrt)); err != nil {
+	if err := http.IgnoreNotFound(s.resolveIssuer(mcrt, id).Delete(ctx, sslCertificateName, mcrt)); err != nil {
 		return err
 	}
 
@@ -129,77 +129,264 @@ func (s syncImpl) deleteSslCertificate(ctx context.Context, mcrt *apisv1beta2.Ma
 	return nil
 }
 
-func (s syncImpl) ensureSslCertificate(ctx context.Context, sslCertificateName string, id types.CertId,
-	mcrt *apisv1beta2.ManagedCertificate) (*compute.SslCertificate, error) {
+// deleteRetiringSslCertificate deletes a certificate that has already been superseded by a promoted
+// replacement. Callers that can't guarantee the new SslCertificate has been live long enough for the
+// ingress controller to have converged onto it - i.e. everywhere but teardown - must gate this on
+// config.MinRetiringAge themselves; see ensureSslCertificate.
+func (s syncImpl) deleteRetiringSslCertificate(ctx context.Context, mcrt *apisv1beta2.ManagedCertificate, id types.CertId,
+	sslCertificateName string) error {
+
+	klog.Infof("Delete retiring SslCertificate %s for ManagedCertificate %s", sslCertificateName, id.String())
+	if err := http.IgnoreNotFound(s.resolveIssuer(mcrt, id).Delete(ctx, sslCertificateName, mcrt)); err != nil {
+		return err
+	}
+
+	s.state.ClearRetiring(id)
+	return nil
+}
+
+// deletePendingSslCertificate deletes a certificate created to replace the current one mid-rotation,
+// before it was ever promoted.
+func (s syncImpl) deletePendingSslCertificate(ctx context.Context, mcrt *apisv1beta2.ManagedCertificate, id types.CertId,
+	sslCertificateName string) error {
+
+	klog.Infof("Delete pending SslCertificate %s for ManagedCertificate %s", sslCertificateName, id.String())
+	return http.IgnoreNotFound(s.resolveIssuer(mcrt, id).Delete(ctx, sslCertificateName, mcrt))
+}
 
-	exists, err := s.ssl.Exists(sslCertificateName, mcrt)
+// deleteAllSslCertificates tears down every SslCertificate tracked for id - current, and, if a
+// rotation was underway, pending and retiring too - so that removing a ManagedCertificate never
+// leaks quota for an in-progress rotation.
+func (s syncImpl) deleteAllSslCertificates(ctx context.Context, mcrt *apisv1beta2.ManagedCertificate, id types.CertId) error {
+	current, pending, retiring, err := s.state.GetSslCertificateNames(id)
+	if err != nil {
+		return err
+	}
+
+	if pending != "" {
+		if err := s.deletePendingSslCertificate(ctx, mcrt, id, pending); err != nil {
+			return err
+		}
+	}
+
+	if retiring != "" {
+		if err := s.deleteRetiringSslCertificate(ctx, mcrt, id, retiring); err != nil {
+			return err
+		}
+	}
+
+	return s.deleteSslCertificate(ctx, mcrt, id, current)
+}
+
+// ensureCurrentSslCertificate makes sure the certificate named current exists, creating it - and
+// assigning current a name first, if this is the first sync for id - if necessary.
+func (s syncImpl) ensureCurrentSslCertificate(ctx context.Context, iss issuer.Issuer, current string, id types.CertId,
+	mcrt *apisv1beta2.ManagedCertificate) (*issuer.Certificate, error) {
+
+	if current == "" {
+		sslCertificateName, err := s.random.Name()
+		if err != nil {
+			return nil, err
+		}
+
+		klog.Infof("Add to state SslCertificate name %s for ManagedCertificate %s", sslCertificateName, id.String())
+		s.state.SetSslCertificateName(id, sslCertificateName)
+		current = sslCertificateName
+	}
+
+	exists, err := iss.Exists(current, mcrt)
 	if err != nil {
 		return nil, err
 	}
 
 	if !exists {
-		if err := s.ssl.Create(ctx, sslCertificateName, *mcrt); err != nil {
+		if err := iss.Create(ctx, current, *mcrt); err != nil {
 			return nil, err
 		}
 
-		if err := s.observeSslCertificateCreationLatencyIfNeeded(sslCertificateName, id, *mcrt); err != nil {
+		if err := s.observeSslCertificateCreationLatencyIfNeeded(current, id, *mcrt); err != nil {
 			return nil, err
 		}
 	}
 
-	sslCert, err := s.ssl.Get(sslCertificateName, mcrt)
+	return iss.Get(current, mcrt)
+}
+
+// ensureSslCertificate reconciles the certificate(s) backing mcrt using whichever Issuer backend
+// mcrt.Spec.IssuerRef selects, overlapping a new certificate with the current one while domains are
+// changing - or a rotation was force-requested, e.g. by the expirer controller ahead of expiry -
+// instead of deleting the current one outright. It returns the certificate that should now be
+// considered current, and - while a retiring certificate is still being published in status
+// alongside it - the retiring one too.
+func (s syncImpl) ensureSslCertificate(ctx context.Context, id types.CertId,
+	mcrt *apisv1beta2.ManagedCertificate) (*issuer.Certificate, *issuer.Certificate, error) {
+
+	issuerRef := s.resolveIssuerRef(mcrt, id)
+	iss := s.resolveIssuer(mcrt, id)
+	s.state.SetIssuerRef(id, issuerRef)
+
+	current, pending, retiring, err := s.state.GetSslCertificateNames(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if retiring != "" {
+		retiringSince, err := s.state.RetiringSince(id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if age := time.Since(retiringSince); age < s.config.MinRetiringAge {
+			klog.Infof("Retiring SslCertificate %s for ManagedCertificate %s has only been retiring for %s, "+
+				"waiting until %s before deleting it so the ingress controller has time to converge onto its replacement",
+				retiring, id.String(), age, s.config.MinRetiringAge)
+		} else if err := s.deleteRetiringSslCertificate(ctx, mcrt, id, retiring); err != nil {
+			return nil, nil, err
+		} else {
+			retiring = ""
+		}
+	}
+
+	// retiringCert is published in mcrt's status alongside whatever is returned as current below, so
+	// that the ingress controller keeps it attached to the target proxy until MinRetiringAge has
+	// elapsed and it is actually deleted above - every return below must carry it. The promotion
+	// branch is reached only once nothing is retiring, since PromotePending replaces the retiring
+	// name in state.
+	var retiringCert *issuer.Certificate
+	if retiring != "" {
+		retiringCert, err = iss.Get(retiring, mcrt)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if pending != "" {
+		// A rotation is already underway, so any pending force-rotation request has already been
+		// satisfied by it - clear it now rather than leaving it to fire a second, spurious rotation
+		// once this one promotes and pending goes back to "".
+		s.state.ClearForceRotationRequested(id)
+
+		pendingCert, err := iss.Get(pending, mcrt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if pendingCert.Status != issuer.StatusActive {
+			klog.Infof("Pending SslCertificate %s for ManagedCertificate %s is not active yet, keeping %s as current",
+				pending, id.String(), current)
+			currentCert, err := iss.Get(current, mcrt)
+			if err != nil {
+				return nil, nil, err
+			}
+			return currentCert, retiringCert, nil
+		}
+
+		if retiringCert != nil {
+			klog.Infof("Pending SslCertificate %s for ManagedCertificate %s is active, but %s is still retiring, keeping %s as current",
+				pending, id.String(), retiring, current)
+			currentCert, err := iss.Get(current, mcrt)
+			if err != nil {
+				return nil, nil, err
+			}
+			return currentCert, retiringCert, nil
+		}
+
+		klog.Infof("Pending SslCertificate %s for ManagedCertificate %s reached active, promoting it to current and retiring %s",
+			pending, id.String(), current)
+		promotedRetiringCert, err := iss.Get(current, mcrt)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.state.PromotePending(id); err != nil {
+			return nil, nil, err
+		}
+		return pendingCert, promotedRetiringCert, nil
+	}
+
+	currentCert, err := s.ensureCurrentSslCertificate(ctx, iss, current, id, mcrt)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if certificates.Equal(*mcrt, *sslCert) {
-		return sslCert, nil
+	forceRotation, err := s.state.IsForceRotationRequested(id)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	klog.Infof("ManagedCertificate %v and SslCertificate %v are different", mcrt, sslCert)
-	if err := s.deleteSslCertificate(ctx, mcrt, id, sslCertificateName); err != nil {
-		return nil, err
+	if !forceRotation && iss.Equal(*mcrt, *currentCert) {
+		return currentCert, retiringCert, nil
 	}
 
-	return nil, errors.ErrSslCertificateOutOfSyncGotDeleted
+	if !s.config.FeatureEnabled(config.FeatureMultiCertOverlap) {
+		klog.Infof("ManagedCertificate %v and SslCertificate %v are different, and %s is disabled, deleting the SslCertificate",
+			mcrt, currentCert, config.FeatureMultiCertOverlap)
+		// deleteSslCertificate removes the whole state entry, taking any pending force-rotation
+		// request with it, so there is nothing left to clear here.
+		if err := s.deleteSslCertificate(ctx, mcrt, id, current); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.ErrSslCertificateOutOfSyncGotDeleted
+	}
+
+	sslCertificateName, err := s.random.Name()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	klog.Infof("ManagedCertificate %v and SslCertificate %v are different, provisioning replacement SslCertificate %s",
+		mcrt, currentCert, sslCertificateName)
+	if err := iss.Create(ctx, sslCertificateName, *mcrt); err != nil {
+		return nil, nil, err
+	}
+	s.state.StartRotation(id, sslCertificateName)
+	// Only clear the force-rotation request once its replacement has actually been created and
+	// recorded - if Create or StartRotation above had failed, the request must survive to retry on
+	// the next sync.
+	s.state.ClearForceRotationRequested(id)
+
+	return currentCert, retiringCert, nil
 }
 
 func (s syncImpl) ManagedCertificate(ctx context.Context, id types.CertId) error {
 	mcrt, err := s.lister.ManagedCertificates(id.Namespace).Get(id.Name)
 	if http.IsNotFound(err) {
-		sslCertificateName, err := s.state.GetSslCertificateName(id)
-		if err == errors.ErrManagedCertificateNotFound {
+		if _, err := s.state.GetSslCertificateName(id); err == errors.ErrManagedCertificateNotFound {
 			return nil
 		} else if err != nil {
 			return err
 		}
 
 		klog.Infof("ManagedCertificate %s already deleted", id.String())
-		return s.deleteSslCertificate(ctx, nil, id, sslCertificateName)
+		return s.deleteAllSslCertificates(ctx, nil, id)
 	} else if err != nil {
 		return err
 	}
 
 	klog.Infof("Syncing ManagedCertificate %s", id.String())
 
-	sslCertificateName, err := s.ensureSslCertificateName(id)
-	if err != nil {
+	// Bootstrap a state entry for id if this is its first sync, so IsSoftDeleted below does not
+	// treat a brand-new ManagedCertificate as not-found and abort the reconcile before
+	// ensureSslCertificate ever runs. The actual SslCertificate name is assigned lazily, in
+	// ensureCurrentSslCertificate.
+	if _, err := s.state.GetSslCertificateName(id); err == errors.ErrManagedCertificateNotFound {
+		s.state.SetSslCertificateName(id, "")
+	} else if err != nil {
 		return err
 	}
 
 	if softDeleted, err := s.state.IsSoftDeleted(id); err != nil {
 		return err
 	} else if softDeleted {
-		klog.Infof("ManagedCertificate %s is soft deleted, deleting SslCertificate %s", id.String(), sslCertificateName)
-		return s.deleteSslCertificate(ctx, mcrt, id, sslCertificateName)
+		klog.Infof("ManagedCertificate %s is soft deleted, deleting its SslCertificates", id.String())
+		return s.deleteAllSslCertificates(ctx, mcrt, id)
 	}
 
-	sslCert, err := s.ensureSslCertificate(ctx, sslCertificateName, id, mcrt)
+	cert, retiringCert, err := s.ensureSslCertificate(ctx, id, mcrt)
 	if err != nil {
 		return err
 	}
 
-	if err := certificates.CopyStatus(*sslCert, mcrt, s.config); err != nil {
+	if err := certificates.CopyStatus(*cert, retiringCert, mcrt, s.config); err != nil {
 		return err
 	}
 
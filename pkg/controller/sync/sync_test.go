@@ -0,0 +1,336 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/networking.gke.io/v1beta2"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/errors"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/issuer"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/controller/state"
+	"github.com/GoogleCloudPlatform/gke-managed-certs/pkg/utils/types"
+)
+
+// fakeIssuer is a minimal issuer.Issuer stand-in, keyed by certificate name, so tests can drive
+// ensureSslCertificate's decisions without a real GCP or cert-manager backend.
+type fakeIssuer struct {
+	certs  map[string]issuer.Certificate
+	equal  bool
+	exists bool
+
+	created []string
+	deleted []string
+}
+
+func (f *fakeIssuer) Exists(name string, mcrt *apisv1beta2.ManagedCertificate) (bool, error) {
+	return f.exists, nil
+}
+
+func (f *fakeIssuer) Create(ctx context.Context, name string, mcrt apisv1beta2.ManagedCertificate) error {
+	f.created = append(f.created, name)
+	return nil
+}
+
+func (f *fakeIssuer) Get(name string, mcrt *apisv1beta2.ManagedCertificate) (*issuer.Certificate, error) {
+	cert := f.certs[name]
+	return &cert, nil
+}
+
+func (f *fakeIssuer) Delete(ctx context.Context, name string, mcrt *apisv1beta2.ManagedCertificate) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func (f *fakeIssuer) Equal(mcrt apisv1beta2.ManagedCertificate, cert issuer.Certificate) bool {
+	return f.equal
+}
+
+// fakeRandom is a random.Random stand-in returning a fixed name.
+type fakeRandom struct {
+	name string
+}
+
+func (f fakeRandom) Name() (string, error) {
+	return f.name, nil
+}
+
+// fakeMetrics is a metrics.Metrics stand-in that discards everything reported to it.
+type fakeMetrics struct{}
+
+func (fakeMetrics) ObserveSslCertificateCreationLatency(createdAt time.Time)                      {}
+func (fakeMetrics) ObserveSslCertificateTimeToExpiry(id types.CertId, timeToExpiry time.Duration) {}
+
+func newTestSyncImpl(iss issuer.Issuer, st state.State, cfg *config.Config) syncImpl {
+	return syncImpl{
+		config:  cfg,
+		issuers: map[string]issuer.Issuer{issuer.DefaultIssuerRef: iss},
+		metrics: fakeMetrics{},
+		random:  fakeRandom{name: "cert-2"},
+		state:   st,
+	}
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		MinRetiringAge: 2 * time.Minute,
+		FeatureGates:   map[string]bool{config.FeatureMultiCertOverlap: true},
+	}
+}
+
+var testId = types.CertId{Namespace: "default", Name: "mcrt"}
+
+func testMcrt(domains ...string) *apisv1beta2.ManagedCertificate {
+	mcrt := &apisv1beta2.ManagedCertificate{ObjectMeta: metav1.ObjectMeta{Namespace: testId.Namespace, Name: testId.Name}}
+	mcrt.Spec.Domains = domains
+	return mcrt
+}
+
+func TestEnsureSslCertificateCreatesFirstCertificate(t *testing.T) {
+	st := state.New()
+	st.SetSslCertificateName(testId, "")
+	iss := &fakeIssuer{
+		exists: false,
+		equal:  true,
+		certs:  map[string]issuer.Certificate{"cert-2": {Name: "cert-2", Status: issuer.StatusProvisioning}},
+	}
+	s := newTestSyncImpl(iss, st, testConfig())
+
+	cert, retiringCert, err := s.ensureSslCertificate(context.Background(), testId, testMcrt("example.com"))
+	if err != nil {
+		t.Fatalf("ensureSslCertificate() returned error %v", err)
+	}
+	if retiringCert != nil {
+		t.Fatalf("retiringCert = %v, want nil when bootstrapping the first certificate", retiringCert)
+	}
+	if cert.Name != "cert-2" {
+		t.Fatalf("cert.Name = %q, want cert-2", cert.Name)
+	}
+	if len(iss.created) != 1 || iss.created[0] != "cert-2" {
+		t.Fatalf("created = %v, want [cert-2]", iss.created)
+	}
+}
+
+func TestEnsureSslCertificatePendingNotActiveKeepsCurrent(t *testing.T) {
+	st := state.New()
+	st.SetSslCertificateName(testId, "cert-1")
+	st.StartRotation(testId, "cert-2")
+	iss := &fakeIssuer{
+		certs: map[string]issuer.Certificate{
+			"cert-1": {Name: "cert-1", Status: issuer.StatusActive},
+			"cert-2": {Name: "cert-2", Status: issuer.StatusProvisioning},
+		},
+	}
+	s := newTestSyncImpl(iss, st, testConfig())
+
+	cert, retiringCert, err := s.ensureSslCertificate(context.Background(), testId, testMcrt("example.com"))
+	if err != nil {
+		t.Fatalf("ensureSslCertificate() returned error %v", err)
+	}
+	if retiringCert != nil {
+		t.Fatalf("retiringCert = %v, want nil while the pending SslCertificate is not active yet", retiringCert)
+	}
+	if cert.Name != "cert-1" {
+		t.Fatalf("cert.Name = %q, want cert-1 kept as current", cert.Name)
+	}
+
+	if current, pending, _, err := st.GetSslCertificateNames(testId); err != nil || current != "cert-1" || pending != "cert-2" {
+		t.Fatalf("GetSslCertificateNames() = (%q, %q, _, %v), want (cert-1, cert-2, _, nil) unchanged", current, pending, err)
+	}
+}
+
+func TestEnsureSslCertificatePromotesActivePending(t *testing.T) {
+	st := state.New()
+	st.SetSslCertificateName(testId, "cert-1")
+	st.StartRotation(testId, "cert-2")
+	iss := &fakeIssuer{
+		certs: map[string]issuer.Certificate{
+			"cert-1": {Name: "cert-1", Status: issuer.StatusActive},
+			"cert-2": {Name: "cert-2", Status: issuer.StatusActive},
+		},
+	}
+	s := newTestSyncImpl(iss, st, testConfig())
+
+	cert, retiringCert, err := s.ensureSslCertificate(context.Background(), testId, testMcrt("example.com"))
+	if err != nil {
+		t.Fatalf("ensureSslCertificate() returned error %v", err)
+	}
+	if cert.Name != "cert-2" {
+		t.Fatalf("cert.Name = %q, want cert-2 promoted to current", cert.Name)
+	}
+	if retiringCert == nil || retiringCert.Name != "cert-1" {
+		t.Fatalf("retiringCert = %v, want cert-1 demoted to retiring", retiringCert)
+	}
+
+	current, pending, retiring, err := st.GetSslCertificateNames(testId)
+	if err != nil {
+		t.Fatalf("GetSslCertificateNames() returned error %v", err)
+	}
+	if current != "cert-2" || pending != "" || retiring != "cert-1" {
+		t.Fatalf("GetSslCertificateNames() = (%q, %q, %q), want (cert-2, \"\", cert-1)", current, pending, retiring)
+	}
+}
+
+func TestEnsureSslCertificateStartsRotationOnDomainChange(t *testing.T) {
+	st := state.New()
+	st.SetSslCertificateName(testId, "cert-1")
+	iss := &fakeIssuer{
+		exists: true,
+		equal:  false,
+		certs:  map[string]issuer.Certificate{"cert-1": {Name: "cert-1", Status: issuer.StatusActive, Domains: []string{"old.example.com"}}},
+	}
+	s := newTestSyncImpl(iss, st, testConfig())
+
+	cert, retiringCert, err := s.ensureSslCertificate(context.Background(), testId, testMcrt("new.example.com"))
+	if err != nil {
+		t.Fatalf("ensureSslCertificate() returned error %v", err)
+	}
+	if retiringCert != nil {
+		t.Fatalf("retiringCert = %v, want nil: the old certificate keeps serving until the new one is active", retiringCert)
+	}
+	if cert.Name != "cert-1" {
+		t.Fatalf("cert.Name = %q, want cert-1 kept current while cert-2 provisions", cert.Name)
+	}
+
+	if _, pending, _, err := st.GetSslCertificateNames(testId); err != nil || pending != "cert-2" {
+		t.Fatalf("GetSslCertificateNames() pending = %q, %v, want cert-2, nil", pending, err)
+	}
+	if len(iss.created) != 1 || iss.created[0] != "cert-2" {
+		t.Fatalf("created = %v, want [cert-2]", iss.created)
+	}
+}
+
+func TestEnsureSslCertificateForceRotationStartsOverlapEvenWhenEqual(t *testing.T) {
+	st := state.New()
+	st.SetSslCertificateName(testId, "cert-1")
+	if err := st.SetForceRotationRequested(testId); err != nil {
+		t.Fatalf("SetForceRotationRequested() returned error %v", err)
+	}
+	iss := &fakeIssuer{
+		exists: true,
+		equal:  true,
+		certs:  map[string]issuer.Certificate{"cert-1": {Name: "cert-1", Status: issuer.StatusActive, Domains: []string{"example.com"}}},
+	}
+	s := newTestSyncImpl(iss, st, testConfig())
+
+	if _, _, err := s.ensureSslCertificate(context.Background(), testId, testMcrt("example.com")); err != nil {
+		t.Fatalf("ensureSslCertificate() returned error %v", err)
+	}
+
+	if _, pending, _, err := st.GetSslCertificateNames(testId); err != nil || pending != "cert-2" {
+		t.Fatalf("GetSslCertificateNames() pending = %q, %v, want cert-2, nil: a force-requested rotation must start an overlap even though domains match", pending, err)
+	}
+	if forceRotation, err := st.IsForceRotationRequested(testId); err != nil || forceRotation {
+		t.Fatalf("IsForceRotationRequested() = %v, %v, want false, nil once the rotation it requested has started", forceRotation, err)
+	}
+}
+
+func TestEnsureSslCertificateDeletesWhenOverlapDisabled(t *testing.T) {
+	st := state.New()
+	st.SetSslCertificateName(testId, "cert-1")
+	iss := &fakeIssuer{
+		exists: true,
+		equal:  false,
+		certs:  map[string]issuer.Certificate{"cert-1": {Name: "cert-1", Status: issuer.StatusActive, Domains: []string{"old.example.com"}}},
+	}
+	cfg := testConfig()
+	cfg.FeatureGates[config.FeatureMultiCertOverlap] = false
+	s := newTestSyncImpl(iss, st, cfg)
+
+	_, _, err := s.ensureSslCertificate(context.Background(), testId, testMcrt("new.example.com"))
+	if err != errors.ErrSslCertificateOutOfSyncGotDeleted {
+		t.Fatalf("ensureSslCertificate() returned error %v, want ErrSslCertificateOutOfSyncGotDeleted", err)
+	}
+	if len(iss.deleted) != 1 || iss.deleted[0] != "cert-1" {
+		t.Fatalf("deleted = %v, want [cert-1]", iss.deleted)
+	}
+	if _, err := st.GetSslCertificateName(testId); err != errors.ErrManagedCertificateNotFound {
+		t.Fatalf("GetSslCertificateName() returned error %v, want ErrManagedCertificateNotFound: the state entry must be removed", err)
+	}
+}
+
+func TestEnsureSslCertificateKeepsRetiringUnderMinAge(t *testing.T) {
+	st := state.New()
+	st.SetSslCertificateName(testId, "cert-1")
+	st.StartRotation(testId, "cert-2")
+	if err := st.PromotePending(testId); err != nil {
+		t.Fatalf("PromotePending() returned error %v", err)
+	}
+	iss := &fakeIssuer{
+		exists: true,
+		equal:  true,
+		certs: map[string]issuer.Certificate{
+			"cert-1": {Name: "cert-1", Status: issuer.StatusActive},
+			"cert-2": {Name: "cert-2", Status: issuer.StatusActive, Domains: []string{"example.com"}},
+		},
+	}
+	s := newTestSyncImpl(iss, st, testConfig())
+
+	_, retiringCert, err := s.ensureSslCertificate(context.Background(), testId, testMcrt("example.com"))
+	if err != nil {
+		t.Fatalf("ensureSslCertificate() returned error %v", err)
+	}
+	if retiringCert == nil || retiringCert.Name != "cert-1" {
+		t.Fatalf("retiringCert = %v, want cert-1: it must keep being published in status until MinRetiringAge elapses", retiringCert)
+	}
+
+	if len(iss.deleted) != 0 {
+		t.Fatalf("deleted = %v, want none: cert-1 has not been retiring for MinRetiringAge yet", iss.deleted)
+	}
+	if _, _, retiring, err := st.GetSslCertificateNames(testId); err != nil || retiring != "cert-1" {
+		t.Fatalf("GetSslCertificateNames() retiring = %q, %v, want cert-1, nil", retiring, err)
+	}
+}
+
+func TestEnsureSslCertificateDeletesRetiringAfterMinAge(t *testing.T) {
+	st := state.New()
+	st.SetSslCertificateName(testId, "cert-1")
+	st.StartRotation(testId, "cert-2")
+	if err := st.PromotePending(testId); err != nil {
+		t.Fatalf("PromotePending() returned error %v", err)
+	}
+	iss := &fakeIssuer{
+		exists: true,
+		equal:  true,
+		certs: map[string]issuer.Certificate{
+			"cert-2": {Name: "cert-2", Status: issuer.StatusActive, Domains: []string{"example.com"}},
+		},
+	}
+	cfg := testConfig()
+	cfg.MinRetiringAge = 0
+	s := newTestSyncImpl(iss, st, cfg)
+
+	_, retiringCert, err := s.ensureSslCertificate(context.Background(), testId, testMcrt("example.com"))
+	if err != nil {
+		t.Fatalf("ensureSslCertificate() returned error %v", err)
+	}
+	if retiringCert != nil {
+		t.Fatalf("retiringCert = %v, want nil: cert-1 was just deleted this call", retiringCert)
+	}
+
+	if len(iss.deleted) != 1 || iss.deleted[0] != "cert-1" {
+		t.Fatalf("deleted = %v, want [cert-1] once MinRetiringAge has elapsed", iss.deleted)
+	}
+	if _, _, retiring, err := st.GetSslCertificateNames(testId); err != nil || retiring != "" {
+		t.Fatalf("GetSslCertificateNames() retiring = %q, %v, want \"\", nil", retiring, err)
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance is a Ginkgo-based conformance suite that exercises the full ManagedCertificate
+// sync flow against a live cluster. It is compiled to a standalone conformance.test binary with
+// `ginkgo build` and parameterized with --namespace and --issuer so the same suite can validate
+// alternative issuer backends.
+//
+// The suite does not cover the BackendError or TooManyCertificates events: both require injecting a
+// Compute API fault (a broken service account, or a project with its SslCertificate quota actually
+// exhausted) and no fault-injection harness exists yet. Out of scope until one lands.
+package conformance
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConformance(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "gke-managed-certs conformance suite")
+}
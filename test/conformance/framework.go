@@ -0,0 +1,35 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"flag"
+	"time"
+)
+
+var (
+	namespace        = flag.String("namespace", "default", "Namespace to create test ManagedCertificates in")
+	issuerRef        = flag.String("issuer", "gcp", "issuerRef to exercise against: gcp or certmanager")
+	multiCertOverlap = flag.Bool("multi-cert-overlap", true,
+		"whether the controller under test has the MultiCertOverlap feature gate enabled; set to false when "+
+			"testing against a controller that explicitly disabled it, to skip overlap-dependent assertions")
+)
+
+const (
+	pollInterval = 10 * time.Second
+	pollTimeout  = 20 * time.Minute
+)
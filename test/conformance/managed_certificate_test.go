@@ -0,0 +1,149 @@
+/*
+Copyright 2020 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	apisv1beta2 "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/apis/networking.gke.io/v1beta2"
+	clientset "github.com/GoogleCloudPlatform/gke-managed-certs/pkg/clientgen/clientset/versioned"
+)
+
+var _ = Describe("ManagedCertificate", func() {
+	var (
+		client    clientset.Interface
+		k8sClient kubernetes.Interface
+	)
+
+	BeforeEach(func() {
+		cfg, err := rest.InClusterConfig()
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err = clientset.NewForConfig(cfg)
+		Expect(err).NotTo(HaveOccurred())
+
+		k8sClient, err = kubernetes.NewForConfig(cfg)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("creates an SslCertificate and propagates its status", func() {
+		mcrt := newManagedCertificate("create", []string{"create.example.com"})
+
+		created, err := client.NetworkingV1beta2().ManagedCertificates(*namespace).Create(mcrt)
+		Expect(err).NotTo(HaveOccurred())
+		defer deleteManagedCertificate(client, created.Name)
+
+		waitForCertificateName(client, created.Name)
+
+		Expect(hasEvent(k8sClient, created.Name, "Create")).To(BeTrue())
+	})
+
+	It("rotates the SslCertificate when domains change, without a coverage gap", func() {
+		if !*multiCertOverlap {
+			Skip("MultiCertOverlap feature gate is disabled against this cluster; rotation here deletes and " +
+				"recreates the SslCertificate instead of overlapping, leaving a coverage gap")
+		}
+
+		mcrt := newManagedCertificate("rotate", []string{"rotate-a.example.com"})
+
+		created, err := client.NetworkingV1beta2().ManagedCertificates(*namespace).Create(mcrt)
+		Expect(err).NotTo(HaveOccurred())
+		defer deleteManagedCertificate(client, created.Name)
+
+		waitForCertificateName(client, created.Name)
+
+		initial, err := client.NetworkingV1beta2().ManagedCertificates(*namespace).Get(created.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		initialName := initial.Status.CertificateName
+
+		initial.Spec.Domains = []string{"rotate-b.example.com"}
+		_, err = client.NetworkingV1beta2().ManagedCertificates(*namespace).Update(initial)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() []string {
+			mcrt, err := client.NetworkingV1beta2().ManagedCertificates(*namespace).Get(created.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			return mcrt.Status.SslCertificateNames
+		}, pollTimeout, pollInterval).Should(And(HaveLen(2), ContainElement(initialName)),
+			"the retiring SslCertificate should still be published alongside the new one while it is draining")
+
+		Eventually(func() string {
+			mcrt, err := client.NetworkingV1beta2().ManagedCertificates(*namespace).Get(created.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			return mcrt.Status.CertificateName
+		}, pollTimeout, pollInterval).ShouldNot(Equal(initialName))
+	})
+
+	It("soft-deletes the SslCertificate and emits a Delete event", func() {
+		mcrt := newManagedCertificate("delete", []string{"delete.example.com"})
+
+		created, err := client.NetworkingV1beta2().ManagedCertificates(*namespace).Create(mcrt)
+		Expect(err).NotTo(HaveOccurred())
+
+		waitForCertificateName(client, created.Name)
+
+		Expect(client.NetworkingV1beta2().ManagedCertificates(*namespace).Delete(created.Name, &metav1.DeleteOptions{})).To(Succeed())
+
+		Eventually(func() bool {
+			return hasEvent(k8sClient, created.Name, "Delete")
+		}, pollTimeout, pollInterval).Should(BeTrue())
+	})
+
+	// BackendError and TooManyCertificates are not covered here: see the package doc comment in
+	// conformance_suite_test.go for why.
+})
+
+func newManagedCertificate(namePrefix string, domains []string) *apisv1beta2.ManagedCertificate {
+	mcrt := &apisv1beta2.ManagedCertificate{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("conformance-%s-", namePrefix),
+		},
+	}
+	mcrt.Spec.Domains = domains
+	if *issuerRef != "" {
+		mcrt.Spec.IssuerRef.Name = *issuerRef
+	}
+	return mcrt
+}
+
+func waitForCertificateName(client clientset.Interface, name string) {
+	Eventually(func() string {
+		mcrt, err := client.NetworkingV1beta2().ManagedCertificates(*namespace).Get(name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return mcrt.Status.CertificateName
+	}, pollTimeout, pollInterval).ShouldNot(BeEmpty())
+}
+
+func deleteManagedCertificate(client clientset.Interface, name string) {
+	_ = client.NetworkingV1beta2().ManagedCertificates(*namespace).Delete(name, &metav1.DeleteOptions{})
+}
+
+func hasEvent(k8sClient kubernetes.Interface, involvedName, reason string) bool {
+	events, err := k8sClient.CoreV1().Events(*namespace).List(context.Background(), metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.name=%s,reason=%s", involvedName, reason),
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return len(events.Items) > 0
+}